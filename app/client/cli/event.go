@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"time"
 
+	"github.com/aau-network-security/go-ntp/app/client/cli/output"
 	pb "github.com/aau-network-security/go-ntp/daemon/proto"
 	"github.com/spf13/cobra"
 )
 
+var outputFormat string
+
 func (c *Client) CmdEvent() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "event",
@@ -17,23 +21,32 @@ func (c *Client) CmdEvent() *cobra.Command {
 		Args:  cobra.MinimumNArgs(1),
 	}
 
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format: table, json, yaml, jsonl")
+
 	cmd.AddCommand(
 		c.CmdEventCreate(),
 		c.CmdEventStop(),
 		c.CmdEventList(),
 		c.CmdEventTeams(),
-		c.CmdEventTeamRestart())
+		c.CmdEventTeamRestart(),
+		c.CmdEventDump(),
+		c.CmdEventSchedule())
+	cmd.AddCommand(c.LoadPluginCommands()...)
 
 	return cmd
 }
 
 func (c *Client) CmdEventCreate() *cobra.Command {
 	var (
-		name      string
-		buffer    int
-		capacity  int
-		frontends []string
-		exercises []string
+		name       string
+		buffer     int
+		capacity   int
+		frontends  []string
+		exercises  []string
+		startAt    string
+		endAt      string
+		ramp       string
+		scoreboard string
 	)
 
 	cmd := &cobra.Command{
@@ -44,14 +57,31 @@ func (c *Client) CmdEventCreate() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 			defer cancel()
 
+			if startAt != "" {
+				if _, err := time.Parse(time.RFC3339, startAt); err != nil {
+					PrintError(fmt.Sprintf("invalid --start-at: %s", err))
+					return
+				}
+			}
+			if endAt != "" {
+				if _, err := time.Parse(time.RFC3339, endAt); err != nil {
+					PrintError(fmt.Sprintf("invalid --end-at: %s", err))
+					return
+				}
+			}
+
 			tag := args[0]
 			stream, err := c.rpcClient.CreateEvent(ctx, &pb.CreateEventRequest{
-				Name:      name,
-				Tag:       tag,
-				Frontends: frontends,
-				Exercises: exercises,
-				Capacity:  int32(capacity),
-				Buffer:    int32(buffer),
+				Name:       name,
+				Tag:        tag,
+				Frontends:  frontends,
+				Exercises:  exercises,
+				Capacity:   int32(capacity),
+				Buffer:     int32(buffer),
+				StartAt:    startAt,
+				EndAt:      endAt,
+				Ramp:       ramp,
+				Scoreboard: scoreboard,
 			})
 			if err != nil {
 				PrintError(err.Error())
@@ -78,6 +108,10 @@ func (c *Client) CmdEventCreate() *cobra.Command {
 	cmd.Flags().IntVarP(&capacity, "capacity", "c", 10, "capacity of total amount of labs")
 	cmd.Flags().StringSliceVarP(&frontends, "frontends", "f", []string{}, "list of frontends to have for each lab")
 	cmd.Flags().StringSliceVarP(&exercises, "exercises", "e", []string{}, "list of exercises to have for each lab")
+	cmd.Flags().StringVar(&startAt, "start-at", "", "RFC3339 time before which lab requests are refused")
+	cmd.Flags().StringVar(&endAt, "end-at", "", "RFC3339 time at which the event is automatically stopped")
+	cmd.Flags().StringVar(&ramp, "ramp", "", "buffer ramp schedule, e.g. \"2:5m,5:15m,10:30m\" to grow the buffer to N labs after T")
+	cmd.Flags().StringVar(&scoreboard, "scoreboard", "", "scoring/registration backend to use (\"ctfd\", the default, or \"inmemory\")")
 	cmd.MarkFlagRequired("name")
 
 	return cmd
@@ -130,22 +164,24 @@ func (c *Client) CmdEventList() *cobra.Command {
 				return
 			}
 
-			f := formatter{
-				header: []string{"EVENT TAG", "NAME", "# TEAM", "# EXERCISES", "CAPACITY"},
-				fields: []string{"Tag", "Name", "TeamCount", "ExerciseCount", "Capacity"},
+			renderer, err := output.New(output.Format(outputFormat))
+			if err != nil {
+				PrintError(err.Error())
+				return
 			}
 
-			var elements []formatElement
+			var elements []interface{}
 			for _, e := range r.Events {
 				elements = append(elements, e)
 			}
 
-			table, err := f.AsTable(elements)
-			if err != nil {
+			header := []string{"EVENT TAG", "NAME", "# TEAM", "# EXERCISES", "CAPACITY"}
+			fields := []string{"Tag", "Name", "TeamCount", "ExerciseCount", "Capacity"}
+
+			if err := renderer.Render(os.Stdout, header, fields, elements); err != nil {
 				PrintError("Failed to create event list")
 				return
 			}
-			fmt.Printf(table)
 		},
 	}
 }
@@ -169,11 +205,24 @@ func (c *Client) CmdEventTeams() *cobra.Command {
 				return
 			}
 
-			for _, team := range r.Teams {
-				fmt.Printf("%s\n", team.Name)
-				fmt.Printf("- %s\n", team.LabTag)
+			renderer, err := output.New(output.Format(outputFormat))
+			if err != nil {
+				PrintError(err.Error())
+				return
+			}
+
+			var elements []interface{}
+			for _, t := range r.Teams {
+				elements = append(elements, t)
 			}
 
+			header := []string{"TEAM", "LAB TAG"}
+			fields := []string{"Name", "LabTag"}
+
+			if err := renderer.Render(os.Stdout, header, fields, elements); err != nil {
+				PrintError("Failed to list event teams")
+				return
+			}
 		},
 	}
 }
@@ -214,3 +263,114 @@ func (c *Client) CmdEventTeamRestart() *cobra.Command {
 		},
 	}
 }
+
+func (c *Client) CmdEventDump() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "dump [tag]",
+		Short: "Download a support bundle for an event",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			tag := args[0]
+			stream, err := c.rpcClient.DumpEvent(ctx, &pb.DumpEventRequest{
+				Tag: tag,
+			})
+			if err != nil {
+				PrintError(err.Error())
+				return
+			}
+
+			w := os.Stdout
+			if file != "-" {
+				path := file
+				if path == "" {
+					path = fmt.Sprintf("support-%s-%d.tgz", tag, time.Now().Unix())
+				}
+
+				f, err := os.Create(path)
+				if err != nil {
+					PrintError(err.Error())
+					return
+				}
+				defer f.Close()
+				w = f
+			}
+
+			for {
+				chunk, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					PrintError(err.Error())
+					return
+				}
+
+				if _, err := w.Write(chunk.Data); err != nil {
+					PrintError(err.Error())
+					return
+				}
+			}
+
+			if file != "-" {
+				fmt.Printf("Support bundle for event %q written to %s\n", tag, w.Name())
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write the support bundle to (defaults to support-<tag>-<timestamp>.tgz, \"-\" for stdout)")
+
+	return cmd
+}
+
+func (c *Client) CmdEventSchedule() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Actions to perform on event schedules",
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(c.CmdEventScheduleList())
+
+	return cmd
+}
+
+func (c *Client) CmdEventScheduleList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List upcoming start/ramp/stop transitions for scheduled events",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			r, err := c.rpcClient.ListEventSchedules(ctx, &pb.ListEventSchedulesRequest{})
+			if err != nil {
+				PrintError(err.Error())
+				return
+			}
+
+			renderer, err := output.New(output.Format(outputFormat))
+			if err != nil {
+				PrintError(err.Error())
+				return
+			}
+
+			var elements []interface{}
+			for _, s := range r.Schedules {
+				elements = append(elements, s)
+			}
+
+			header := []string{"EVENT TAG", "STARTS AT", "ENDS AT", "NEXT RAMP"}
+			fields := []string{"Tag", "StartAt", "EndAt", "NextRamp"}
+
+			if err := renderer.Render(os.Stdout, header, fields, elements); err != nil {
+				PrintError("Failed to create schedule list")
+				return
+			}
+		},
+	}
+}
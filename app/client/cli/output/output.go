@@ -0,0 +1,102 @@
+// Package output holds the Renderer implementations behind the event
+// commands' -o/--output flag: a Format selects one of table, json, yaml or
+// jsonl, and New builds the Renderer for it.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Format is a supported rendering for list-style CLI output.
+type Format string
+
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+	JSONL Format = "jsonl"
+)
+
+// Renderer writes a slice of elements to w, reading header/fields for
+// formats (like Table) that need to know which struct fields to print and
+// under what name.
+type Renderer interface {
+	Render(w io.Writer, header []string, fields []string, elements []interface{}) error
+}
+
+// New returns the Renderer for f, or an error if f isn't supported.
+func New(f Format) (Renderer, error) {
+	switch f {
+	case Table, "":
+		return tableRenderer{}, nil
+	case JSON:
+		return jsonRenderer{}, nil
+	case YAML:
+		return yamlRenderer{}, nil
+	case JSONL:
+		return jsonlRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", f)
+	}
+}
+
+type tableRenderer struct{}
+
+func (tableRenderer) Render(w io.Writer, header []string, fields []string, elements []interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, e := range elements {
+		v := reflect.ValueOf(e)
+		for v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = fmt.Sprintf("%v", v.FieldByName(field).Interface())
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, header []string, fields []string, elements []interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(elements)
+}
+
+type yamlRenderer struct{}
+
+func (yamlRenderer) Render(w io.Writer, header []string, fields []string, elements []interface{}) error {
+	out, err := yaml.Marshal(elements)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(out)
+	return err
+}
+
+type jsonlRenderer struct{}
+
+func (jsonlRenderer) Render(w io.Writer, header []string, fields []string, elements []interface{}) error {
+	enc := json.NewEncoder(w)
+	for _, e := range elements {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type row struct {
+	Tag  string
+	Name string
+}
+
+var (
+	header   = []string{"TAG", "NAME"}
+	fields   = []string{"Tag", "Name"}
+	elements = []interface{}{
+		&row{Tag: "t1", Name: "one"},
+		&row{Tag: "t2", Name: "two"},
+	}
+)
+
+func render(t *testing.T, f Format) string {
+	t.Helper()
+
+	r, err := New(f)
+	if err != nil {
+		t.Fatalf("New(%q): %s", f, err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, header, fields, elements); err != nil {
+		t.Fatalf("Render: %s", err)
+	}
+
+	return buf.String()
+}
+
+func TestTableRenderer(t *testing.T) {
+	out := render(t, Table)
+
+	if !strings.Contains(out, "TAG") || !strings.Contains(out, "NAME") {
+		t.Fatalf("missing header in output: %q", out)
+	}
+	if !strings.Contains(out, "t1") || !strings.Contains(out, "two") {
+		t.Fatalf("missing row data in output: %q", out)
+	}
+}
+
+func TestJSONRenderer(t *testing.T) {
+	out := render(t, JSON)
+
+	if !strings.Contains(out, `"Tag": "t1"`) {
+		t.Fatalf("output doesn't look like indented JSON: %q", out)
+	}
+}
+
+func TestYAMLRenderer(t *testing.T) {
+	out := render(t, YAML)
+
+	if !strings.Contains(out, "tag: t1") {
+		t.Fatalf("output doesn't look like YAML: %q", out)
+	}
+}
+
+func TestJSONLRenderer(t *testing.T) {
+	out := render(t, JSONL)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != len(elements) {
+		t.Fatalf("got %d lines, want %d: %q", len(lines), len(elements), out)
+	}
+	if !strings.Contains(lines[0], `"Tag":"t1"`) {
+		t.Fatalf("first line doesn't look like compact JSON: %q", lines[0])
+	}
+}
+
+func TestNewUnsupportedFormat(t *testing.T) {
+	if _, err := New("xml"); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
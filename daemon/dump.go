@@ -0,0 +1,165 @@
+package daemon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	pb "github.com/aau-network-security/go-ntp/daemon/proto"
+)
+
+const dumpChunkSize = 32 * 1024
+
+// EventDumper assembles the support bundle served by the DumpEvent RPC: the
+// event's config, each team's lab manifest, docker inspect output for CTFd
+// and every lab's exercise containers, recent daemon logs, and a snapshot
+// of the CTFd database directory. Each field is a lookup against the
+// running event, kept separate from the streaming/archiving mechanics below
+// so tests can stub them out.
+//
+// Containers returns inspect output already marshalled to JSON, keyed by
+// container ID, rather than docker.Container values: docker.Container only
+// exposes ID/Start/Stop/Close, so inspecting is the caller's job against
+// whatever lower-level docker client it has access to.
+type EventDumper struct {
+	ConfigYAML   func(tag string) ([]byte, error)
+	LabManifests func(tag string) (map[string][]byte, error)
+	Containers   func(tag string) (map[string][]byte, error)
+	Logs         func(tag string) ([]byte, error)
+	CTFdDBDir    func(tag string) (string, error)
+}
+
+// Dump writes a tar.gz support bundle for tag to stream, chunked so the RPC
+// doesn't have to hold the whole archive in memory.
+func (d *EventDumper) Dump(tag string, stream pb.Daemon_DumpEventServer) error {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(d.write(tag, pw))
+	}()
+
+	buf := make([]byte, dumpChunkSize)
+	for {
+		n, err := pr.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			if sendErr := stream.Send(&pb.DumpEventResponse{Data: chunk}); sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (d *EventDumper) write(tag string, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	if d.ConfigYAML != nil {
+		conf, err := d.ConfigYAML(tag)
+		if err != nil {
+			return fmt.Errorf("event config: %w", err)
+		}
+		if err := addTarFile(tw, "event.yml", conf); err != nil {
+			return err
+		}
+	}
+
+	if d.LabManifests != nil {
+		manifests, err := d.LabManifests(tag)
+		if err != nil {
+			return fmt.Errorf("lab manifests: %w", err)
+		}
+		for team, manifest := range manifests {
+			name := fmt.Sprintf("labs/%s.yml", team)
+			if err := addTarFile(tw, name, manifest); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.Containers != nil {
+		inspects, err := d.Containers(tag)
+		if err != nil {
+			return fmt.Errorf("containers: %w", err)
+		}
+		for id, inspect := range inspects {
+			name := fmt.Sprintf("containers/%s.json", id)
+			if err := addTarFile(tw, name, inspect); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.Logs != nil {
+		logs, err := d.Logs(tag)
+		if err != nil {
+			return fmt.Errorf("logs: %w", err)
+		}
+		if err := addTarFile(tw, "daemon.log", logs); err != nil {
+			return err
+		}
+	}
+
+	if d.CTFdDBDir != nil {
+		dir, err := d.CTFdDBDir(tag)
+		if err != nil {
+			return fmt.Errorf("ctfd db: %w", err)
+		}
+		if err := addTarDir(tw, "ctfd-db", dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addTarFile(tw *tar.Writer, name string, content []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(content)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+func addTarDir(tw *tar.Writer, prefix, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		return addTarFile(tw, filepath.Join(prefix, rel), content)
+	})
+}
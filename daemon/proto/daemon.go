@@ -0,0 +1,144 @@
+// Package proto holds the Go types for daemon.proto. It is hand-maintained
+// rather than protoc-generated for now; the message and service shapes here
+// must stay in sync with daemon.proto until this package is regenerated
+// properly.
+package proto
+
+import "context"
+
+type Progress struct {
+	Status string
+}
+
+type CreateEventRequest struct {
+	Name      string
+	Tag       string
+	Frontends []string
+	Exercises []string
+	Capacity  int32
+	Buffer    int32
+	StartAt   string
+	EndAt     string
+	Ramp      string
+	// Scoreboard selects the ctf.Scoreboard backend ("ctfd", the default,
+	// or "inmemory").
+	Scoreboard string
+}
+
+type StopEventRequest struct {
+	Tag string
+}
+
+type ListEventsRequest struct{}
+
+type ListEventsResponse struct {
+	Events []*Event
+}
+
+type Event struct {
+	Tag           string
+	Name          string
+	TeamCount     int32
+	ExerciseCount int32
+	Capacity      int32
+}
+
+type ListEventTeamsRequest struct {
+	Tag string
+}
+
+type ListEventTeamsResponse struct {
+	Teams []*Team
+}
+
+type Team struct {
+	Name   string
+	LabTag string
+}
+
+type RestartTeamLabRequest struct {
+	EventTag string
+	LabTag   string
+}
+
+type DumpEventRequest struct {
+	Tag string
+}
+
+type DumpEventResponse struct {
+	Data []byte
+}
+
+type ListEventSchedulesRequest struct{}
+
+type ListEventSchedulesResponse struct {
+	Schedules []*EventSchedule
+}
+
+type EventSchedule struct {
+	Tag      string
+	StartAt  string
+	EndAt    string
+	NextRamp string
+}
+
+// The Daemon_*Client interfaces below are the client-side handles for the
+// service's streaming RPCs; each wraps a grpc.ClientStream once this package
+// is regenerated from daemon.proto.
+type Daemon_CreateEventClient interface {
+	Recv() (*Progress, error)
+}
+
+type Daemon_StopEventClient interface {
+	Recv() (*Progress, error)
+}
+
+type Daemon_RestartTeamLabClient interface {
+	Recv() (*Progress, error)
+}
+
+type Daemon_DumpEventClient interface {
+	Recv() (*DumpEventResponse, error)
+}
+
+// The Daemon_*Server interfaces are the server-side counterparts, used by
+// the daemon to push stream messages back to the CLI.
+type Daemon_CreateEventServer interface {
+	Send(*Progress) error
+}
+
+type Daemon_StopEventServer interface {
+	Send(*Progress) error
+}
+
+type Daemon_RestartTeamLabServer interface {
+	Send(*Progress) error
+}
+
+type Daemon_DumpEventServer interface {
+	Send(*DumpEventResponse) error
+}
+
+// DaemonClient is the client side of the Daemon service, implemented by the
+// generated grpc client that app/client/cli.Client.rpcClient holds.
+type DaemonClient interface {
+	CreateEvent(ctx context.Context, in *CreateEventRequest) (Daemon_CreateEventClient, error)
+	StopEvent(ctx context.Context, in *StopEventRequest) (Daemon_StopEventClient, error)
+	ListEvents(ctx context.Context, in *ListEventsRequest) (*ListEventsResponse, error)
+	ListEventTeams(ctx context.Context, in *ListEventTeamsRequest) (*ListEventTeamsResponse, error)
+	RestartTeamLab(ctx context.Context, in *RestartTeamLabRequest) (Daemon_RestartTeamLabClient, error)
+	DumpEvent(ctx context.Context, in *DumpEventRequest) (Daemon_DumpEventClient, error)
+	ListEventSchedules(ctx context.Context, in *ListEventSchedulesRequest) (*ListEventSchedulesResponse, error)
+}
+
+// DaemonServer is the server side of the Daemon service, implemented by the
+// daemon package.
+type DaemonServer interface {
+	CreateEvent(in *CreateEventRequest, stream Daemon_CreateEventServer) error
+	StopEvent(in *StopEventRequest, stream Daemon_StopEventServer) error
+	ListEvents(ctx context.Context, in *ListEventsRequest) (*ListEventsResponse, error)
+	ListEventTeams(ctx context.Context, in *ListEventTeamsRequest) (*ListEventTeamsResponse, error)
+	RestartTeamLab(in *RestartTeamLabRequest, stream Daemon_RestartTeamLabServer) error
+	DumpEvent(in *DumpEventRequest, stream Daemon_DumpEventServer) error
+	ListEventSchedules(ctx context.Context, in *ListEventSchedulesRequest) (*ListEventSchedulesResponse, error)
+}
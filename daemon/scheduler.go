@@ -0,0 +1,199 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/aau-network-security/go-ntp/daemon/proto"
+	"github.com/rs/zerolog/log"
+)
+
+// RampStep is one step of a buffer ramp schedule: grow the buffered lab
+// pool to Buffer labs, After elapses since the event's start.
+type RampStep struct {
+	Buffer int
+	After  time.Duration
+}
+
+// ParseRamp parses a ramp schedule like "2:5m,5:15m,10:30m" into the steps
+// CmdEventCreate's --ramp flag describes.
+func ParseRamp(s string) ([]RampStep, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var steps []RampStep
+	for _, part := range strings.Split(s, ",") {
+		n, dur, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed ramp step %q, want N:duration", part)
+		}
+
+		buffer, err := strconv.Atoi(strings.TrimSpace(n))
+		if err != nil {
+			return nil, fmt.Errorf("malformed ramp step %q: %w", part, err)
+		}
+
+		after, err := time.ParseDuration(strings.TrimSpace(dur))
+		if err != nil {
+			return nil, fmt.Errorf("malformed ramp step %q: %w", part, err)
+		}
+
+		steps = append(steps, RampStep{Buffer: buffer, After: after})
+	}
+
+	return steps, nil
+}
+
+// Scheduler drives one event's lifecycle against its --start-at, --end-at
+// and --ramp configuration: it refuses lab requests before StartAt, grows
+// the buffered lab pool as each ramp step comes due, and stops the event at
+// EndAt.
+type Scheduler struct {
+	Tag     string
+	StartAt time.Time
+	EndAt   time.Time
+	Ramp    []RampStep
+
+	// SetBuffer grows the event's buffered lab pool to n labs.
+	SetBuffer func(n int) error
+	// Stop is the equivalent of StopEvent for this event.
+	Stop func() error
+
+	mu   sync.RWMutex
+	next *RampStep
+}
+
+// Ready reports whether lab requests should currently be served; it is
+// false before StartAt.
+func (s *Scheduler) Ready() bool {
+	return s.StartAt.IsZero() || !time.Now().Before(s.StartAt)
+}
+
+// NextRamp describes the next pending ramp step, for `hkn event schedule
+// list`, or the empty string once the ramp schedule is exhausted.
+func (s *Scheduler) NextRamp() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.next == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d labs at %s", s.next.Buffer, s.next.After)
+}
+
+// Run waits for StartAt, grows the buffer per the ramp schedule, and
+// auto-stops the event at EndAt, returning once the last of these is done or
+// ctx is cancelled. It does not gate lab requests itself; Ready does. Ramp
+// steps are anchored to StartAt, or to the moment Run is called if StartAt
+// is unset, so an event created without --start-at still ramps 5m/15m/...
+// after creation instead of immediately.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.setNext(0)
+
+	anchor := s.StartAt
+	if anchor.IsZero() {
+		anchor = time.Now()
+	}
+
+	if !s.StartAt.IsZero() {
+		if !s.waitUntil(ctx, s.StartAt) {
+			return
+		}
+	}
+
+	for i, step := range s.Ramp {
+		s.setNext(i)
+
+		if !s.waitUntil(ctx, anchor.Add(step.After)) {
+			return
+		}
+
+		if err := s.SetBuffer(step.Buffer); err != nil {
+			log.Warn().Err(err).Str("tag", s.Tag).Int("buffer", step.Buffer).Msg("failed to grow event buffer")
+		}
+	}
+	s.setNext(len(s.Ramp))
+
+	if !s.EndAt.IsZero() {
+		if !s.waitUntil(ctx, s.EndAt) {
+			return
+		}
+
+		if err := s.Stop(); err != nil {
+			log.Warn().Err(err).Str("tag", s.Tag).Msg("failed to auto-stop event")
+		}
+	}
+}
+
+func (s *Scheduler) setNext(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i < len(s.Ramp) {
+		s.next = &s.Ramp[i]
+	} else {
+		s.next = nil
+	}
+}
+
+func (s *Scheduler) waitUntil(ctx context.Context, t time.Time) bool {
+	select {
+	case <-time.After(time.Until(t)):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// SchedulerRegistry tracks every running event's Scheduler, so `hkn event
+// schedule list` has something to read.
+type SchedulerRegistry struct {
+	mu         sync.RWMutex
+	schedulers map[string]*Scheduler
+}
+
+func NewSchedulerRegistry() *SchedulerRegistry {
+	return &SchedulerRegistry{schedulers: make(map[string]*Scheduler)}
+}
+
+func (r *SchedulerRegistry) Register(s *Scheduler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedulers[s.Tag] = s
+}
+
+func (r *SchedulerRegistry) Unregister(tag string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.schedulers, tag)
+}
+
+// ListEventSchedules implements the corresponding DaemonServer RPC.
+func (r *SchedulerRegistry) ListEventSchedules(ctx context.Context, in *pb.ListEventSchedulesRequest) (*pb.ListEventSchedulesResponse, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	resp := &pb.ListEventSchedulesResponse{}
+	for _, s := range r.schedulers {
+		resp.Schedules = append(resp.Schedules, &pb.EventSchedule{
+			Tag:      s.Tag,
+			StartAt:  formatTime(s.StartAt),
+			EndAt:    formatTime(s.EndAt),
+			NextRamp: s.NextRamp(),
+		})
+	}
+
+	return resp, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,84 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseRamp(t *testing.T) {
+	steps, err := ParseRamp("2:5m,5:15m,10:30m")
+	if err != nil {
+		t.Fatalf("ParseRamp: %s", err)
+	}
+
+	want := []RampStep{
+		{Buffer: 2, After: 5 * time.Minute},
+		{Buffer: 5, After: 15 * time.Minute},
+		{Buffer: 10, After: 30 * time.Minute},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("got %d steps, want %d", len(steps), len(want))
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Fatalf("step %d = %+v, want %+v", i, steps[i], want[i])
+		}
+	}
+}
+
+func TestParseRampEmpty(t *testing.T) {
+	steps, err := ParseRamp("")
+	if err != nil {
+		t.Fatalf("ParseRamp: %s", err)
+	}
+	if steps != nil {
+		t.Fatalf("got %+v, want nil", steps)
+	}
+}
+
+func TestParseRampMalformed(t *testing.T) {
+	cases := []string{"5m", "2:5x", "x:5m"}
+	for _, c := range cases {
+		if _, err := ParseRamp(c); err == nil {
+			t.Errorf("ParseRamp(%q): expected an error", c)
+		}
+	}
+}
+
+// TestSchedulerRampWithoutStartAt guards against anchoring ramp steps to the
+// zero time.Time when --start-at isn't set, which used to fire every step
+// within microseconds of each other instead of after's worth of time
+// following Run's call.
+func TestSchedulerRampWithoutStartAt(t *testing.T) {
+	var mu sync.Mutex
+	var fired time.Time
+
+	s := &Scheduler{
+		Tag:  "t1",
+		Ramp: []RampStep{{Buffer: 1, After: 50 * time.Millisecond}},
+		SetBuffer: func(n int) error {
+			mu.Lock()
+			fired = time.Now()
+			mu.Unlock()
+			return nil
+		},
+		Stop: func() error { return nil },
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	s.Run(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fired.IsZero() {
+		t.Fatal("SetBuffer was never called")
+	}
+	if elapsed := fired.Sub(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("ramp step fired after %s, want at least ~50ms", elapsed)
+	}
+}
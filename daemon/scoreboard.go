@@ -0,0 +1,23 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/aau-network-security/go-ntp/svcs/ctf"
+	"github.com/aau-network-security/go-ntp/svcs/ctf/inmemory"
+	"github.com/aau-network-security/go-ntp/svcs/ctfd"
+)
+
+// NewScoreboard builds the ctf.Scoreboard backend selected by kind, as set
+// by CreateEventRequest.Scoreboard. The empty string defaults to "ctfd" so
+// existing events that don't set the field keep their current behavior.
+func NewScoreboard(kind string, conf ctf.Config) (ctf.Scoreboard, error) {
+	switch kind {
+	case "", "ctfd":
+		return ctfd.New(conf)
+	case "inmemory":
+		return inmemory.New(conf)
+	default:
+		return nil, fmt.Errorf("unknown scoreboard backend %q", kind)
+	}
+}
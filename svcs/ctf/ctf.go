@@ -0,0 +1,50 @@
+// Package ctf holds the Scoreboard interface and the types its
+// implementations share, so the rest of the daemon can drive an event's
+// scoring/registration backend without importing a specific one.
+package ctf
+
+import (
+	"github.com/aau-network-security/go-ntp/exercise"
+	"github.com/aau-network-security/go-ntp/svcs/revproxy"
+	"github.com/aau-network-security/go-ntp/virtual/docker"
+)
+
+// Team is a registered participant as reported by a Scoreboard backend.
+type Team struct {
+	Id    string
+	Name  string
+	Email string
+}
+
+// Config holds the parameters shared by every Scoreboard implementation.
+type Config struct {
+	Name       string `yaml:"name"`
+	AdminUser  string `yaml:"admin_user"`
+	AdminEmail string `yaml:"admin_email"`
+	AdminPass  string `yaml:"admin_pass"`
+	Flags      []exercise.FlagConfig
+}
+
+// Scoreboard is satisfied by every scoring/registration backend an event can
+// use, e.g. ctfd.New or inmemory.New.
+type Scoreboard interface {
+	docker.Identifier
+	revproxy.Connector
+
+	Start() error
+	Close() error
+	Stop() error
+
+	// Configure applies conf to the backend, registering its admin account
+	// and any flags it already declares.
+	Configure(Config) error
+
+	// AddFlags registers additional flags after Configure has run.
+	AddFlags([]exercise.FlagConfig) error
+
+	// Flags returns every flag currently registered with the backend.
+	Flags() []exercise.FlagConfig
+
+	// Teams lists the teams currently registered with the backend.
+	Teams() ([]Team, error)
+}
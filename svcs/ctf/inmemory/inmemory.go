@@ -0,0 +1,189 @@
+// Package inmemory implements ctf.Scoreboard directly on top of an
+// in-process HTTP server: registration and flag submission are handled
+// here, with no separate container or external frontend involved.
+package inmemory
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aau-network-security/go-ntp/exercise"
+	"github.com/aau-network-security/go-ntp/svcs/ctf"
+	"github.com/aau-network-security/go-ntp/virtual/docker"
+	"github.com/google/uuid"
+)
+
+// startGrace is how long Start waits for Serve to fail outright before
+// declaring success.
+const startGrace = 200 * time.Millisecond
+
+type registerRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type submitRequest struct {
+	TeamId string `json:"team_id"`
+	Flag   string `json:"flag"`
+}
+
+type scoreboard struct {
+	m     sync.RWMutex
+	id    string
+	conf  ctf.Config
+	teams map[string]*ctf.Team
+	srv   *http.Server
+	port  int
+}
+
+// New builds a Scoreboard backed by an in-process HTTP server; call Start to
+// bind and begin serving it.
+func New(conf ctf.Config) (ctf.Scoreboard, error) {
+	sb := &scoreboard{
+		id:    uuid.New().String(),
+		teams: make(map[string]*ctf.Team),
+	}
+
+	if err := sb.Configure(conf); err != nil {
+		return nil, err
+	}
+
+	return sb, nil
+}
+
+func (sb *scoreboard) Configure(conf ctf.Config) error {
+	sb.m.Lock()
+	defer sb.m.Unlock()
+
+	sb.conf = conf
+	return nil
+}
+
+func (sb *scoreboard) AddFlags(flags []exercise.FlagConfig) error {
+	sb.m.Lock()
+	defer sb.m.Unlock()
+
+	sb.conf.Flags = append(sb.conf.Flags, flags...)
+	return nil
+}
+
+func (sb *scoreboard) Flags() []exercise.FlagConfig {
+	sb.m.RLock()
+	defer sb.m.RUnlock()
+
+	return sb.conf.Flags
+}
+
+func (sb *scoreboard) Teams() ([]ctf.Team, error) {
+	sb.m.RLock()
+	defer sb.m.RUnlock()
+
+	var teams []ctf.Team
+	for _, t := range sb.teams {
+		teams = append(teams, *t)
+	}
+
+	return teams, nil
+}
+
+// Start binds an ephemeral, per-instance port (so concurrent events don't
+// collide on a shared one) and serves registration and flag submission from
+// it. A bind failure is returned straight from the listen call; it then waits
+// out a short grace period so a Serve failure is returned to the caller
+// instead of only surfacing on the first request.
+func (sb *scoreboard) Start() error {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	sb.port = ln.Addr().(*net.TCPAddr).Port
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/register", sb.handleRegister)
+	mux.HandleFunc("/submit", sb.handleSubmit)
+	sb.srv = &http.Server{Handler: mux}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- sb.srv.Serve(ln) }()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("serve: %w", err)
+	case <-time.After(startGrace):
+		return nil
+	}
+}
+
+func (sb *scoreboard) Stop() error {
+	if sb.srv == nil {
+		return nil
+	}
+
+	return sb.srv.Close()
+}
+
+func (sb *scoreboard) Close() error {
+	return sb.Stop()
+}
+
+func (sb *scoreboard) ID() string {
+	return sb.id
+}
+
+func (sb *scoreboard) ConnectProxy() (docker.Identifier, string) {
+	conf := fmt.Sprintf(`location / {
+        proxy_pass http://{{.Host}}:%d/;
+    }`, sb.port)
+	return sb, conf
+}
+
+func (sb *scoreboard) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var req registerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	team := &ctf.Team{
+		Id:    uuid.New().String(),
+		Name:  req.Name,
+		Email: req.Email,
+	}
+
+	sb.m.Lock()
+	sb.teams[team.Id] = team
+	sb.m.Unlock()
+
+	json.NewEncoder(w).Encode(team)
+}
+
+func (sb *scoreboard) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req submitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sb.m.RLock()
+	_, ok := sb.teams[req.TeamId]
+	flags := sb.conf.Flags
+	sb.m.RUnlock()
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown team %q", req.TeamId), http.StatusNotFound)
+		return
+	}
+
+	for _, flag := range flags {
+		if flag.Default == req.Flag {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	http.Error(w, "incorrect flag", http.StatusBadRequest)
+}
@@ -0,0 +1,87 @@
+package inmemory
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aau-network-security/go-ntp/exercise"
+	"github.com/aau-network-security/go-ntp/svcs/ctf"
+)
+
+func newTestScoreboard(t *testing.T) *scoreboard {
+	t.Helper()
+
+	sb, err := New(ctf.Config{
+		Flags: []exercise.FlagConfig{{Default: "flag{test}"}},
+	})
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+
+	return sb.(*scoreboard)
+}
+
+func TestHandleRegister(t *testing.T) {
+	sb := newTestScoreboard(t)
+
+	body, _ := json.Marshal(registerRequest{Name: "team1", Email: "team1@example.com"})
+	req := httptest.NewRequest("POST", "/register", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	sb.handleRegister(w, req)
+
+	if w.Code != 200 {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	var team ctf.Team
+	if err := json.NewDecoder(w.Body).Decode(&team); err != nil {
+		t.Fatalf("decode response: %s", err)
+	}
+	if team.Name != "team1" || team.Email != "team1@example.com" {
+		t.Fatalf("unexpected team: %+v", team)
+	}
+
+	teams, err := sb.Teams()
+	if err != nil {
+		t.Fatalf("Teams: %s", err)
+	}
+	if len(teams) != 1 {
+		t.Fatalf("len(teams) = %d, want 1", len(teams))
+	}
+}
+
+func TestHandleSubmit(t *testing.T) {
+	sb := newTestScoreboard(t)
+
+	regBody, _ := json.Marshal(registerRequest{Name: "team1"})
+	regW := httptest.NewRecorder()
+	sb.handleRegister(regW, httptest.NewRequest("POST", "/register", bytes.NewReader(regBody)))
+
+	var team ctf.Team
+	json.NewDecoder(regW.Body).Decode(&team)
+
+	cases := []struct {
+		name string
+		req  submitRequest
+		want int
+	}{
+		{"correct flag", submitRequest{TeamId: team.Id, Flag: "flag{test}"}, 200},
+		{"wrong flag", submitRequest{TeamId: team.Id, Flag: "flag{wrong}"}, 400},
+		{"unknown team", submitRequest{TeamId: "nope", Flag: "flag{test}"}, 404},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			body, _ := json.Marshal(c.req)
+			w := httptest.NewRecorder()
+			sb.handleSubmit(w, httptest.NewRequest("POST", "/submit", bytes.NewReader(body)))
+
+			if w.Code != c.want {
+				t.Fatalf("status = %d, want %d", w.Code, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package ctfd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aau-network-security/go-ntp/svcs/ctf"
+)
+
+// APIClient is a small typed client around the subset of CTFd's JSON REST
+// API (`/api/v1/...`) that the rest of this package needs. It is
+// authenticated with an admin access token rather than a session cookie, so
+// it keeps working across CTFd upgrades that change the admin HTML.
+type APIClient struct {
+	baseURL    string
+	token      string
+	httpclient *http.Client
+}
+
+// NewAPIClient returns an APIClient that authenticates every request with
+// token against the CTFd instance at baseURL.
+func NewAPIClient(baseURL, token string) *APIClient {
+	return &APIClient{
+		baseURL:    baseURL,
+		token:      token,
+		httpclient: &http.Client{},
+	}
+}
+
+type apiChallenge struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Value uint   `json:"value"`
+}
+
+type apiTeam struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Solve is a single team's accepted submission for a challenge.
+type Solve struct {
+	ChallengeID int    `json:"challenge_id"`
+	TeamID      int    `json:"team_id"`
+	Flag        string `json:"provided"`
+}
+
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (a *APIClient) do(method, path string, body interface{}, out interface{}) error {
+	var reader *bytes.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, a.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Token "+a.token)
+
+	resp, err := a.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("ctfd api: %s %s: unexpected status %d: %s", method, path, resp.StatusCode, content)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(content, &apiResp); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(apiResp.Data, out)
+}
+
+// CreateChallenge creates a standard, visible challenge worth points and
+// returns its id, so a flag can be attached to it with AddFlag.
+func (a *APIClient) CreateChallenge(name string, points uint) (int, error) {
+	body := map[string]interface{}{
+		"name":     name,
+		"category": "",
+		"value":    points,
+		"type":     "standard",
+		"state":    "visible",
+	}
+
+	var chal apiChallenge
+	if err := a.do("POST", "/api/v1/challenges", body, &chal); err != nil {
+		return 0, err
+	}
+
+	return chal.ID, nil
+}
+
+// AddFlag attaches a static flag to the challenge identified by
+// challengeID.
+func (a *APIClient) AddFlag(challengeID int, flag string) error {
+	body := map[string]interface{}{
+		"challenge_id": challengeID,
+		"content":      flag,
+		"type":         "static",
+	}
+
+	return a.do("POST", "/api/v1/flags", body, nil)
+}
+
+// ListTeams returns every team registered with the CTFd instance.
+func (a *APIClient) ListTeams() ([]ctf.Team, error) {
+	var teams []apiTeam
+	if err := a.do("GET", "/api/v1/teams", nil, &teams); err != nil {
+		return nil, err
+	}
+
+	out := make([]ctf.Team, len(teams))
+	for i, t := range teams {
+		out[i] = ctf.Team{Id: fmt.Sprintf("%d", t.ID), Name: t.Name, Email: t.Email}
+	}
+
+	return out, nil
+}
+
+// ListSolves returns every accepted flag submission recorded by the CTFd
+// instance.
+func (a *APIClient) ListSolves() ([]Solve, error) {
+	var solves []Solve
+	if err := a.do("GET", "/api/v1/submissions?type=correct", nil, &solves); err != nil {
+		return nil, err
+	}
+
+	return solves, nil
+}
+
+// createToken issues a new admin access token through the session cookie
+// obtained during initial setup, so subsequent calls no longer need to
+// authenticate against the HTML admin panel.
+func createToken(hc *http.Client, baseURL string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"expiration":  nil,
+		"description": "haaukins",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest("POST", baseURL+"/api/v1/tokens", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("ctfd api: unable to create token: unexpected status %d: %s", resp.StatusCode, content)
+	}
+
+	var tokenResp struct {
+		Data struct {
+			Value string `json:"value"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(content, &tokenResp); err != nil {
+		return "", err
+	}
+
+	return tokenResp.Data.Value, nil
+}
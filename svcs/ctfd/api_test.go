@@ -0,0 +1,115 @@
+package ctfd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClientAuthHeader(t *testing.T) {
+	var gotAuth string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"success":true,"data":[]}`))
+	}))
+	defer srv.Close()
+
+	a := NewAPIClient(srv.URL, "sometoken")
+	if _, err := a.ListTeams(); err != nil {
+		t.Fatalf("ListTeams: %s", err)
+	}
+
+	if gotAuth != "Token sometoken" {
+		t.Fatalf("Authorization header = %q, want %q", gotAuth, "Token sometoken")
+	}
+}
+
+func TestAPIClientCreateChallengeAndAddFlag(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/challenges":
+			w.Write([]byte(`{"success":true,"data":{"id":42,"name":"chal","value":100}}`))
+		case "/api/v1/flags":
+			w.Write([]byte(`{"success":true,"data":null}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	a := NewAPIClient(srv.URL, "sometoken")
+
+	id, err := a.CreateChallenge("chal", 100)
+	if err != nil {
+		t.Fatalf("CreateChallenge: %s", err)
+	}
+	if id != 42 {
+		t.Fatalf("id = %d, want 42", id)
+	}
+
+	if err := a.AddFlag(id, "flag{test}"); err != nil {
+		t.Fatalf("AddFlag: %s", err)
+	}
+}
+
+func TestAPIClientListTeams(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(apiResponse{
+			Success: true,
+			Data:    mustMarshal(t, []apiTeam{{ID: 1, Name: "team1", Email: "team1@example.com"}}),
+		})
+	}))
+	defer srv.Close()
+
+	a := NewAPIClient(srv.URL, "sometoken")
+	teams, err := a.ListTeams()
+	if err != nil {
+		t.Fatalf("ListTeams: %s", err)
+	}
+	if len(teams) != 1 || teams[0].Name != "team1" || teams[0].Id != "1" {
+		t.Fatalf("unexpected teams: %+v", teams)
+	}
+}
+
+func TestAPIClientErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	a := NewAPIClient(srv.URL, "badtoken")
+	if _, err := a.ListTeams(); err == nil {
+		t.Fatal("expected an error for a non-2xx response")
+	}
+}
+
+func TestCreateToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/tokens" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"id":1,"value":"abc123"}}`))
+	}))
+	defer srv.Close()
+
+	token, err := createToken(srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("createToken: %s", err)
+	}
+	if token != "abc123" {
+		t.Fatalf("token = %q, want %q", token, "abc123")
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	return b
+}
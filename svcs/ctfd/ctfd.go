@@ -1,11 +1,9 @@
 package ctfd
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
-	"mime/multipart"
 	"net"
 	"net/http"
 	"net/http/cookiejar"
@@ -18,7 +16,7 @@ import (
 	"errors"
 
 	"github.com/aau-network-security/go-ntp/exercise"
-	"github.com/aau-network-security/go-ntp/svcs/revproxy"
+	"github.com/aau-network-security/go-ntp/svcs/ctf"
 	"github.com/aau-network-security/go-ntp/virtual/docker"
 	"github.com/rs/zerolog/log"
 )
@@ -29,31 +27,25 @@ var (
 	ServerUnavailableErr = errors.New("Server is unavailable")
 )
 
-type CTFd interface {
-	docker.Identifier
-	revproxy.Connector
-	Start() error
-	Close() error
-	Stop() error
-	Flags() []exercise.FlagConfig
-}
+const baseURL = "http://localhost:8000"
 
-type Config struct {
-	Name       string `yaml:"name"`
-	AdminUser  string `yaml:"admin_user"`
-	AdminEmail string `yaml:"admin_email"`
-	AdminPass  string `yaml:"admin_pass"`
-	Flags      []exercise.FlagConfig
-}
+// CTFd is kept as an alias of ctf.Scoreboard so existing callers that still
+// refer to ctfd.CTFd keep compiling.
+type CTFd = ctf.Scoreboard
+
+// Config is kept as an alias of ctf.Config so existing callers that still
+// refer to ctfd.Config keep compiling.
+type Config = ctf.Config
 
 type ctfd struct {
-	conf       Config
+	conf       ctf.Config
 	cont       docker.Container
-    confDir   string
+	confDir    string
 	httpclient *http.Client
+	api        *APIClient
 }
 
-func New(conf Config) (CTFd, error) {
+func New(conf ctf.Config) (ctf.Scoreboard, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, err
@@ -63,8 +55,7 @@ func New(conf Config) (CTFd, error) {
 		Jar: jar,
 	}
 
-	ctf := &ctfd{
-		conf:       conf,
+	c := &ctfd{
 		httpclient: hc,
 	}
 
@@ -78,7 +69,7 @@ func New(conf Config) (CTFd, error) {
 		return nil, err
 	}
 
-    ctf.confDir = confDir
+	c.confDir = confDir
 
 	baseConf := &docker.ContainerConfig{
 		Image: "registry.sec-aau.dk/aau/ctfd",
@@ -96,74 +87,101 @@ func New(conf Config) (CTFd, error) {
 		"8000/tcp": "127.0.0.1:8000",
 	}
 
-	c, err := docker.NewContainer(initConf)
+	cont, err := docker.NewContainer(initConf)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.Start()
+	err = cont.Start()
 	if err != nil {
 		return nil, err
 	}
 
-	err = ctf.configureInstance()
+	err = c.Configure(conf)
 	if err != nil {
 		return nil, err
 	}
 
-	err = c.Close()
+	err = cont.Close()
 	if err != nil {
 		return nil, err
 	}
 
 	finalConf := *baseConf
-	c, err = docker.NewContainer(finalConf)
+	cont, err = docker.NewContainer(finalConf)
 	if err != nil {
 		return nil, err
 	}
-	ctf.cont = c
+	c.cont = cont
 
-	return ctf, nil
+	return c, nil
 
 }
 
-func (ctf *ctfd) Start() error {
-	return ctf.cont.Start()
+func (c *ctfd) Start() error {
+	return c.cont.Start()
 }
 
-func (ctf *ctfd) Close() error {
-	if err := os.RemoveAll(ctf.confDir); err != nil {
+func (c *ctfd) Close() error {
+	if err := os.RemoveAll(c.confDir); err != nil {
 		return err
 	}
 
-	if err := ctf.cont.Close(); err != nil {
+	if err := c.cont.Close(); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (ctf *ctfd) Stop() error {
-	return ctf.cont.Stop()
+func (c *ctfd) Stop() error {
+	return c.cont.Stop()
 }
 
-func (ctf *ctfd) Flags() []exercise.FlagConfig {
-	return ctf.conf.Flags
+func (c *ctfd) Configure(conf ctf.Config) error {
+	c.conf = conf
+	return c.configureInstance()
 }
 
-func (ctf *ctfd) ID() string {
-	return ctf.cont.ID()
+func (c *ctfd) AddFlags(flags []exercise.FlagConfig) error {
+	for _, flag := range flags {
+		if err := c.createFlag(flag.Name, flag.Default, flag.Points); err != nil {
+			return err
+		}
+		log.Debug().
+			Str("name", flag.Name).
+			Str("flag", flag.Default).
+			Uint("points", flag.Points).
+			Msg("Flag created")
+	}
+
+	c.conf.Flags = append(c.conf.Flags, flags...)
+	return nil
+}
+
+func (c *ctfd) Flags() []exercise.FlagConfig {
+	return c.conf.Flags
+}
+
+// Teams returns every team registered with the CTFd instance, read through
+// the admin API rather than the admin HTML.
+func (c *ctfd) Teams() ([]ctf.Team, error) {
+	return c.api.ListTeams()
+}
+
+func (c *ctfd) ID() string {
+	return c.cont.ID()
 }
 
-func (ctf *ctfd) ConnectProxy() (docker.Identifier, string) {
+func (c *ctfd) ConnectProxy() (docker.Identifier, string) {
 	conf := `location / {
         proxy_pass http://{{.Host}}:8000/;
     }`
-    return ctf, conf
+	return c, conf
 }
 
-func (ctf *ctfd) getNonce(path string) (string, error) {
-	resp, err := ctf.httpclient.Get(path)
+func (c *ctfd) getNonce(path string) (string, error) {
+	resp, err := c.httpclient.Get(path)
 	if err != nil {
 		return "", err
 	}
@@ -182,69 +200,35 @@ func (ctf *ctfd) getNonce(path string) (string, error) {
 	return string(matches[0][1]), nil
 }
 
-func (ctf *ctfd) createFlag(name, flag string, points uint) error {
-	endpoint := "http://localhost:8000" + "/admin/chal/new"
-
-	nonce, err := ctf.getNonce(endpoint)
-	if err != nil {
-		return err
-	}
-
-	body := &bytes.Buffer{}
-	w := multipart.NewWriter(body)
-	values := map[string]string{
-		"name":         name,
-		"value":        fmt.Sprintf("%d", points),
-		"key":          flag,
-		"nonce":        nonce,
-		"key_type[0]":  "static",
-		"category":     "",
-		"description":  "",
-		"max_attempts": "",
-		"chaltype":     "standard",
-	}
-
-	for k, v := range values {
-		err := w.WriteField(k, v)
-		if err != nil {
-			return err
-		}
-	}
-	w.Close()
-
-	req, err := http.NewRequest("POST", endpoint, body)
+func (c *ctfd) createFlag(name, flag string, points uint) error {
+	id, err := c.api.CreateChallenge(name, points)
 	if err != nil {
 		return err
 	}
-	req.Header.Add("Content-Type", w.FormDataContentType())
 
-	resp, err := ctf.httpclient.Do(req)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
-
-	return nil
+	return c.api.AddFlag(id, flag)
 }
 
-func (ctf *ctfd) configureInstance() error {
-	endpoint := "http://localhost:8000/setup"
+// configureInstance drives CTFd's initial web setup, which has no API
+// equivalent, then issues an admin access token through the resulting
+// session so every later call can go through the JSON API instead.
+func (c *ctfd) configureInstance() error {
+	endpoint := baseURL + "/setup"
 
 	if err := waitForServer(endpoint); err != nil {
 		return err
 	}
 
-	nonce, err := ctf.getNonce(endpoint)
+	nonce, err := c.getNonce(endpoint)
 	if err != nil {
 		return err
 	}
 
 	form := url.Values{
-		"ctf_name": {ctf.conf.Name},
-		"name":     {ctf.conf.AdminUser},
-		"password": {ctf.conf.AdminPass},
-		"email":    {ctf.conf.AdminEmail},
+		"ctf_name": {c.conf.Name},
+		"name":     {c.conf.AdminUser},
+		"password": {c.conf.AdminPass},
+		"email":    {c.conf.AdminEmail},
 		"nonce":    {nonce},
 	}
 
@@ -254,14 +238,20 @@ func (ctf *ctfd) configureInstance() error {
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := ctf.httpclient.Do(req)
+	resp, err := c.httpclient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	for _, flag := range ctf.conf.Flags {
-		err := ctf.createFlag(flag.Name, flag.Default, flag.Points)
+	token, err := createToken(c.httpclient, baseURL)
+	if err != nil {
+		return err
+	}
+	c.api = NewAPIClient(baseURL, token)
+
+	for _, flag := range c.conf.Flags {
+		err := c.createFlag(flag.Name, flag.Default, flag.Points)
 		if err != nil {
 			return err
 		}